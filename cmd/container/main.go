@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ailyth99/PDO_tex_tool/container"
+	"github.com/Ailyth99/PDO_tex_tool/pcmp"
+)
+
+// parsePCMPMode maps the -mode flag's string value to a pcmp.Mode.
+func parsePCMPMode(s string) (pcmp.Mode, error) {
+	switch s {
+	case "auto":
+		return pcmp.ModeAuto, nil
+	case "lzss":
+		return pcmp.ModeLZSS, nil
+	case "stored":
+		return pcmp.ModeStored, nil
+	default:
+		return 0, fmt.Errorf("unknown -mode %q (want auto, lzss, or stored)", s)
+	}
+}
+
+func main() {
+	decode := flag.Bool("d", false, "decode a container file to its raw payload")
+	encode := flag.Bool("c", false, "encode a raw file into a container format")
+	format := flag.String("format", "pcmp", "container format to use when encoding with -c")
+	blocks := flag.Int("blocks", 0, "pcmp only: compress in parallel KiB-sized blocks (0 disables block mode)")
+	mode := flag.String("mode", "auto", "pcmp only: storage mode to force (auto, lzss, stored)")
+	flag.Parse()
+	args := flag.Args()
+
+	if *decode == *encode {
+		fmt.Fprintln(os.Stderr, "Usage: container -d <file> [outputfile]")
+		fmt.Fprintln(os.Stderr, "       container -c -format=<name> <file> [outputfile]")
+		os.Exit(1)
+	}
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, "Usage: container [-d|-c] [-format=<name>] <inputfile> [outputfile]")
+		os.Exit(1)
+	}
+	inputFile := args[0]
+
+	inputData, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input file %s: %v\n", inputFile, err)
+		os.Exit(1)
+	}
+
+	var outputData []byte
+	var defaultExt string
+	var chosenMode pcmp.Mode
+	if *decode {
+		outputData, err = container.Decode(bytes.NewReader(inputData))
+		defaultExt = ".bin"
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error decoding %s: %v\n", inputFile, err)
+			os.Exit(1)
+		}
+	} else if *format == "pcmp" {
+		pcmpMode, err := parsePCMPMode(*mode)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		var buf bytes.Buffer
+		w := pcmp.NewAlignedWriter(&buf, pcmp.DefaultAlignment)
+		w.SetMode(pcmpMode)
+		w.SetBlockSize(*blocks)
+		if _, err := w.Write(inputData); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding %s as pcmp: %v\n", inputFile, err)
+			os.Exit(1)
+		}
+		if err := w.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding %s as pcmp: %v\n", inputFile, err)
+			os.Exit(1)
+		}
+		outputData = buf.Bytes()
+		defaultExt = ".pcmp"
+		chosenMode = w.Mode()
+	} else {
+		var buf bytes.Buffer
+		if err := container.Encode(&buf, *format, inputData); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding %s as %s: %v\n", inputFile, *format, err)
+			os.Exit(1)
+		}
+		outputData = buf.Bytes()
+		defaultExt = "." + *format
+	}
+
+	outputFile := ""
+	if len(args) == 2 {
+		outputFile = args[1]
+	} else {
+		outputFile = strings.TrimSuffix(inputFile, filepath.Ext(inputFile)) + defaultExt
+	}
+
+	if err := ioutil.WriteFile(outputFile, outputData, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output file %s: %v\n", outputFile, err)
+		os.Exit(1)
+	}
+	if !*decode && *format == "pcmp" {
+		fmt.Printf("Wrote %d bytes to %s (mode: %s)\n", len(outputData), outputFile, chosenMode)
+	} else {
+		fmt.Printf("Wrote %d bytes to %s\n", len(outputData), outputFile)
+	}
+}
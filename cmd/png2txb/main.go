@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Ailyth99/PDO_tex_tool/dxt"
+)
+
+const (
+	txbHeaderSize = 0x20
+)
+
+func encodePNGToBC2(pngPath string) ([]byte, error) {
+	f, err := os.Open(pngPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening PNG file '%s': %w", pngPath, err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding PNG file '%s': %w", pngPath, err)
+	}
+
+	nrgba := dxt.ToNRGBA(img)
+	fmt.Printf("Decoded PNG %s (%dx%d)\n", pngPath, nrgba.Bounds().Dx(), nrgba.Bounds().Dy())
+
+	bc2Data := dxt.EncodeBC2(nrgba)
+	fmt.Printf("Encoded %d bytes of BC2 (DXT3) block data\n", len(bc2Data))
+	return bc2Data, nil
+}
+
+func createNewTXB(originalTXBPath string, bc2Data []byte, outputNewTXBPath string) error {
+	originalTXBFile, err := os.Open(originalTXBPath)
+	if err != nil {
+		return fmt.Errorf("reading original TXB file '%s': %w", originalTXBPath, err)
+	}
+	defer originalTXBFile.Close()
+
+	originalHeader := make([]byte, txbHeaderSize)
+	n, err := originalTXBFile.Read(originalHeader)
+	if err != nil || n < txbHeaderSize {
+		return fmt.Errorf("reading original TXB header (need %d bytes, got %d): %w", txbHeaderSize, n, err)
+	}
+	fmt.Printf("Read original TXB header (%d bytes) from %s\n", len(originalHeader), originalTXBPath)
+
+	fmt.Println("Using original TXB header as-is (no size fields updated yet).")
+
+	newTXBData := append(originalHeader, bc2Data...)
+	fmt.Printf("New TXB data created (header %d bytes + BC2 %d bytes = total %d bytes)\n", len(originalHeader), len(bc2Data), len(newTXBData))
+
+	err = ioutil.WriteFile(outputNewTXBPath, newTXBData, 0644)
+	if err != nil {
+		return fmt.Errorf("writing new TXB file '%s': %w", outputNewTXBPath, err)
+	}
+	fmt.Printf("Successfully created new TXB file: %s\n", outputNewTXBPath)
+	return nil
+}
+
+func main() {
+	if len(os.Args) != 4 {
+		fmt.Fprintf(os.Stderr, "Usage: %s <input_png_path> <original_txb_path> <output_new_txb_path>\n", filepath.Base(os.Args[0]))
+		fmt.Fprintf(os.Stderr, "Example: %s menu_cn.png menu_original.txb menu_new.txb\n", filepath.Base(os.Args[0]))
+		os.Exit(1)
+	}
+
+	inputPNGPath := os.Args[1]
+	originalTXBPath := os.Args[2]
+	outputNewTXBPath := os.Args[3]
+
+	fmt.Println("--- Step 1: Encoding PNG to BC2 (DXT3) ---")
+	bc2Data, err := encodePNGToBC2(inputPNGPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding PNG to BC2: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\n--- Step 2: Creating new TXB file ---")
+	err = createNewTXB(originalTXBPath, bc2Data, outputNewTXBPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating new TXB file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nProcess completed successfully!")
+}
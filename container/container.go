@@ -0,0 +1,63 @@
+// Package container sniffs and dispatches between the LZ-family container
+// formats used by retrogame texture packs, so callers don't need to know
+// which variant a file is before decoding it. PCMP and PKDPX (from PMD)
+// are registered; other variants such as AT4PX have no codec.
+package container
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Codec encodes and decodes one container format's payload.
+type Codec interface {
+	Decode(r io.Reader) ([]byte, error)
+	Encode(w io.Writer, data []byte) error
+}
+
+// maxMagicLen is the longest magic any registered codec sniffs on, e.g.
+// "PKDPX" (5 bytes).
+const maxMagicLen = 5
+
+type registration struct {
+	format string
+	magic  string
+	codec  Codec
+}
+
+var registry []registration
+
+// Register adds a codec under format (used to select it for encoding, via
+// Encode) and magic (its on-disk signature, used to select it for
+// decoding, via Decode). Formats register themselves from init().
+func Register(format, magic string, codec Codec) {
+	registry = append(registry, registration{format: format, magic: magic, codec: codec})
+}
+
+// Decode sniffs r's leading bytes against every registered magic and
+// decodes with the matching codec.
+func Decode(r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	peek, err := br.Peek(maxMagicLen)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("container: reading signature: %w", err)
+	}
+
+	for _, reg := range registry {
+		if len(peek) >= len(reg.magic) && string(peek[:len(reg.magic)]) == reg.magic {
+			return reg.codec.Decode(br)
+		}
+	}
+	return nil, fmt.Errorf("container: no registered codec matches signature %q", peek)
+}
+
+// Encode encodes data using the codec registered under format.
+func Encode(w io.Writer, format string, data []byte) error {
+	for _, reg := range registry {
+		if reg.format == format {
+			return reg.codec.Encode(w, data)
+		}
+	}
+	return fmt.Errorf("container: no codec registered for format %q", format)
+}
@@ -0,0 +1,29 @@
+package container
+
+import (
+	"io"
+
+	"github.com/Ailyth99/PDO_tex_tool/pcmp"
+)
+
+func init() {
+	Register("pcmp", "PCMP", pcmpCodec{})
+}
+
+type pcmpCodec struct{}
+
+func (pcmpCodec) Decode(r io.Reader) ([]byte, error) {
+	pr, err := pcmp.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(pr)
+}
+
+func (pcmpCodec) Encode(w io.Writer, data []byte) error {
+	pw := pcmp.NewWriter(w)
+	if _, err := pw.Write(data); err != nil {
+		return err
+	}
+	return pw.Close()
+}
@@ -0,0 +1,104 @@
+package container
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	pkdpxMagic      = "PKDPX"
+	pkdpxHeaderSize = 20 // 5-byte magic + u16 container length + 9 control flags + u32 decompressed length
+	pkdpxNumFlags   = 9
+)
+
+var (
+	errPKDPXHeaderShort = errors.New("pkdpx: file too short for PKDPX header")
+	errPKDPXBadMagic    = errors.New("pkdpx: 'PKDPX' signature not found")
+	errPKDPXTruncated   = errors.New("pkdpx: compressed stream ended prematurely")
+	errPKDPXEncodeTODO  = errors.New("pkdpx: encoding is not implemented yet")
+)
+
+func init() {
+	Register("pkdpx", pkdpxMagic, pkdpxCodec{})
+}
+
+type pkdpxCodec struct{}
+
+// PKDPX is a PMD-style LZ container: a 20-byte header is followed by a
+// stream of flag bytes, each governing 8 operations (MSB first). A `1` bit
+// copies one literal byte straight through; a `0` bit reads a 2-byte
+// big-endian control word whose high nibble selects one of the header's 9
+// control-flag bytes (used here as that mode's copy length) and whose low
+// 12 bits are a backreference offset, resolved as
+// pos - ((offset+1) & 0xFFF) - 1 into the output produced so far.
+func (pkdpxCodec) Decode(r io.Reader) ([]byte, error) {
+	header := make([]byte, pkdpxHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errPKDPXHeaderShort
+	}
+	if string(header[:5]) != pkdpxMagic {
+		return nil, errPKDPXBadMagic
+	}
+	controlFlags := header[7 : 7+pkdpxNumFlags]
+	decompressedLength := binary.LittleEndian.Uint32(header[16:20])
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("pkdpx: reading compressed stream: %w", err)
+	}
+
+	out := make([]byte, 0, decompressedLength)
+	idx := 0
+	for uint32(len(out)) < decompressedLength {
+		if idx >= len(data) {
+			return nil, errPKDPXTruncated
+		}
+		flag := data[idx]
+		idx++
+
+		for bit := 7; bit >= 0 && uint32(len(out)) < decompressedLength; bit-- {
+			if (flag>>uint(bit))&1 == 1 {
+				if idx >= len(data) {
+					return nil, errPKDPXTruncated
+				}
+				out = append(out, data[idx])
+				idx++
+				continue
+			}
+
+			if idx+1 >= len(data) {
+				return nil, errPKDPXTruncated
+			}
+			word := binary.BigEndian.Uint16(data[idx : idx+2])
+			idx += 2
+
+			modeIdx := int(word >> 12)
+			offset := int(word & 0x0FFF)
+			if modeIdx >= pkdpxNumFlags {
+				return nil, fmt.Errorf("pkdpx: control word selects out-of-range flag %d", modeIdx)
+			}
+			length := int(controlFlags[modeIdx])
+			if length == 0 {
+				length = 1
+			}
+			backPos := len(out) - ((offset + 1) & 0xFFF) - 1
+
+			for i := 0; i < length && uint32(len(out)) < decompressedLength; i++ {
+				srcPos := backPos + i
+				if srcPos < 0 || srcPos >= len(out) {
+					out = append(out, 0)
+				} else {
+					out = append(out, out[srcPos])
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func (pkdpxCodec) Encode(w io.Writer, data []byte) error {
+	return errPKDPXEncodeTODO
+}
@@ -0,0 +1,82 @@
+package container
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// buildPKDPX assembles a minimal PKDPX fixture: magic, a container-length
+// field (unused by Decode), the 9 control-flag bytes, the declared
+// decompressed length, then the flag-byte-driven body.
+func buildPKDPX(t *testing.T, controlFlags [pkdpxNumFlags]byte, decompressedLength uint32, body []byte) []byte {
+	t.Helper()
+
+	header := make([]byte, pkdpxHeaderSize)
+	copy(header[:5], pkdpxMagic)
+	binary.LittleEndian.PutUint16(header[5:7], uint16(len(header)+len(body)))
+	copy(header[7:7+pkdpxNumFlags], controlFlags[:])
+	binary.LittleEndian.PutUint32(header[16:20], decompressedLength)
+
+	return append(header, body...)
+}
+
+func TestPKDPXDecodeLiteralsAndBackreference(t *testing.T) {
+	// Flag byte 0xC0 (0b11000000): two literals ('A', 'B'), then a
+	// control word. modeIdx 0 selects controlFlags[0] (copy length 4);
+	// offset 0 resolves to backPos 0, so the 4-byte copy reads back over
+	// its own output as it grows, reproducing "ABAB" and reaching the
+	// declared 6-byte length ("AB" + "ABAB").
+	var controlFlags [pkdpxNumFlags]byte
+	controlFlags[0] = 4
+	body := []byte{0xC0, 'A', 'B', 0x00, 0x00}
+	fixture := buildPKDPX(t, controlFlags, 6, body)
+
+	out, err := (pkdpxCodec{}).Decode(bytes.NewReader(fixture))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	want := []byte("ABABAB")
+	if !bytes.Equal(out, want) {
+		t.Fatalf("Decode() = %q, want %q", out, want)
+	}
+}
+
+func TestPKDPXDecodeBadMagic(t *testing.T) {
+	var controlFlags [pkdpxNumFlags]byte
+	fixture := buildPKDPX(t, controlFlags, 1, []byte{0xFF, 'A'})
+	fixture[0] = 'X'
+
+	if _, err := (pkdpxCodec{}).Decode(bytes.NewReader(fixture)); err != errPKDPXBadMagic {
+		t.Fatalf("Decode() err = %v, want errPKDPXBadMagic", err)
+	}
+}
+
+func TestPKDPXDecodeHeaderTooShort(t *testing.T) {
+	if _, err := (pkdpxCodec{}).Decode(bytes.NewReader([]byte(pkdpxMagic))); err != errPKDPXHeaderShort {
+		t.Fatalf("Decode() err = %v, want errPKDPXHeaderShort", err)
+	}
+}
+
+func TestPKDPXDecodeTruncatedStream(t *testing.T) {
+	// Declares 2 decompressed bytes but the body only supplies one
+	// literal before running out.
+	var controlFlags [pkdpxNumFlags]byte
+	fixture := buildPKDPX(t, controlFlags, 2, []byte{0x80, 'A'})
+
+	if _, err := (pkdpxCodec{}).Decode(bytes.NewReader(fixture)); err != errPKDPXTruncated {
+		t.Fatalf("Decode() err = %v, want errPKDPXTruncated", err)
+	}
+}
+
+func TestPKDPXDecodeOutOfRangeFlag(t *testing.T) {
+	// modeIdx is the control word's high nibble; 0xF000 selects flag 15,
+	// which is out of the valid 0-8 range.
+	var controlFlags [pkdpxNumFlags]byte
+	body := []byte{0x00, 0xF0, 0x00}
+	fixture := buildPKDPX(t, controlFlags, 1, body)
+
+	if _, err := (pkdpxCodec{}).Decode(bytes.NewReader(fixture)); err == nil {
+		t.Fatal("Decode() err = nil, want an out-of-range flag error")
+	}
+}
@@ -0,0 +1,181 @@
+// Package dxt implements pure-Go encoding of BC2 (DXT3) texture blocks, so
+// PNG textures can be compressed without shelling out to texconv.exe.
+package dxt
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+const blockSize = 16 // 8 bytes explicit alpha + 8 bytes color per 4x4 block
+
+// ToNRGBA converts img to *image.NRGBA, which EncodeBC2 operates on.
+func ToNRGBA(img image.Image) *image.NRGBA {
+	if n, ok := img.(*image.NRGBA); ok {
+		return n
+	}
+	b := img.Bounds()
+	n := image.NewNRGBA(b)
+	draw.Draw(n, b, img, b.Min, draw.Src)
+	return n
+}
+
+// EncodeBC2 encodes img as a stream of 16-byte BC2 (DXT3) blocks, scanning
+// 4x4 pixel blocks left-to-right, top-to-bottom the way DDS/TXB mip data is
+// laid out. Dimensions that aren't a multiple of 4 are padded by clamping
+// to the nearest edge pixel.
+func EncodeBC2(img *image.NRGBA) []byte {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	blocksWide := (width + 3) / 4
+	blocksHigh := (height + 3) / 4
+
+	out := make([]byte, 0, blocksWide*blocksHigh*blockSize)
+	var block [4 * 4 * 4]byte // 16 pixels, R G B A each
+
+	for by := 0; by < blocksHigh; by++ {
+		for bx := 0; bx < blocksWide; bx++ {
+			readBlock(img, b, b.Min.X+bx*4, b.Min.Y+by*4, &block)
+			out = append(out, encodeBlock(&block)...)
+		}
+	}
+	return out
+}
+
+// readBlock fills block with the 16 RGBA pixels at (x0,y0)-(x0+3,y0+3),
+// clamping out-of-bounds coordinates to the nearest edge pixel. x0 and y0
+// are absolute image coordinates, not relative to bounds.Min, so this
+// works for sub-images whose origin isn't (0,0).
+func readBlock(img *image.NRGBA, bounds image.Rectangle, x0, y0 int, block *[64]byte) {
+	for row := 0; row < 4; row++ {
+		y := y0 + row
+		if y >= bounds.Max.Y {
+			y = bounds.Max.Y - 1
+		}
+		for col := 0; col < 4; col++ {
+			x := x0 + col
+			if x >= bounds.Max.X {
+				x = bounds.Max.X - 1
+			}
+			o := img.PixOffset(x, y)
+			p := img.Pix[o : o+4 : o+4]
+			i := (row*4 + col) * 4
+			copy(block[i:i+4], p)
+		}
+	}
+}
+
+// encodeBlock produces the 16-byte BC2 encoding of a 4x4 RGBA block.
+func encodeBlock(block *[64]byte) []byte {
+	out := make([]byte, blockSize)
+	encodeAlpha(block, out[0:8])
+	encodeColor(block, out[8:16])
+	return out
+}
+
+// encodeAlpha packs each pixel's 8-bit alpha down to 4 bits, two pixels per
+// byte (low nibble first), one byte pair per row.
+func encodeAlpha(block *[64]byte, dst []byte) {
+	for row := 0; row < 4; row++ {
+		var a [4]byte
+		for col := 0; col < 4; col++ {
+			a[col] = block[(row*4+col)*4+3] >> 4
+		}
+		dst[row*2+0] = a[0] | a[1]<<4
+		dst[row*2+1] = a[2] | a[3]<<4
+	}
+}
+
+// encodeColor picks the min/max-luminance pixels as the two RGB565
+// endpoints (a simple first-pass heuristic in place of principal-axis
+// projection), then assigns each pixel to its least-squared-error
+// interpolated color.
+func encodeColor(block *[64]byte, dst []byte) {
+	minIdx, maxIdx := 0, 0
+	minLum, maxLum := 1<<30, -1
+	for i := 0; i < 16; i++ {
+		r, g, b := block[i*4], block[i*4+1], block[i*4+2]
+		lum := luminance(r, g, b)
+		if lum < minLum {
+			minLum, minIdx = lum, i
+		}
+		if lum > maxLum {
+			maxLum, maxIdx = lum, i
+		}
+	}
+
+	c0 := rgbTo565(block[maxIdx*4], block[maxIdx*4+1], block[maxIdx*4+2])
+	c1 := rgbTo565(block[minIdx*4], block[minIdx*4+1], block[minIdx*4+2])
+	if c0 <= c1 {
+		// BC2 always uses the 4-color interpolation mode, which requires
+		// color0 > color1.
+		if c0 == c1 {
+			if c0 == 0xFFFF {
+				c1--
+			} else {
+				c0++
+			}
+		} else {
+			c0, c1 = c1, c0
+		}
+	}
+
+	palette := buildPalette(c0, c1)
+
+	var indices uint32
+	for i := 0; i < 16; i++ {
+		r, g, b := block[i*4], block[i*4+1], block[i*4+2]
+		idx := bestPaletteIndex(palette, r, g, b)
+		indices |= uint32(idx) << (uint(i) * 2)
+	}
+
+	binary.LittleEndian.PutUint16(dst[0:2], c0)
+	binary.LittleEndian.PutUint16(dst[2:4], c1)
+	binary.LittleEndian.PutUint32(dst[4:8], indices)
+}
+
+type rgb struct{ r, g, b int }
+
+// buildPalette expands the two RGB565 endpoints into the 4 BC2 interpolated
+// colors: c0, c1, 2/3 c0 + 1/3 c1, 1/3 c0 + 2/3 c1.
+func buildPalette(c0, c1 uint16) [4]rgb {
+	r0, g0, b0 := unpack565(c0)
+	r1, g1, b1 := unpack565(c1)
+	return [4]rgb{
+		{r0, g0, b0},
+		{r1, g1, b1},
+		{(2*r0 + r1) / 3, (2*g0 + g1) / 3, (2*b0 + b1) / 3},
+		{(r0 + 2*r1) / 3, (g0 + 2*g1) / 3, (b0 + 2*b1) / 3},
+	}
+}
+
+func bestPaletteIndex(palette [4]rgb, r, g, b byte) int {
+	best, bestErr := 0, -1
+	for i, c := range palette {
+		dr, dg, db := int(r)-c.r, int(g)-c.g, int(b)-c.b
+		err := dr*dr + dg*dg + db*db
+		if bestErr == -1 || err < bestErr {
+			best, bestErr = i, err
+		}
+	}
+	return best
+}
+
+func luminance(r, g, b byte) int {
+	return 299*int(r) + 587*int(g) + 114*int(b)
+}
+
+func rgbTo565(r, g, b byte) uint16 {
+	return uint16(r>>3)<<11 | uint16(g>>2)<<5 | uint16(b>>3)
+}
+
+func unpack565(v uint16) (r, g, b int) {
+	r5 := int(v>>11) & 0x1F
+	g6 := int(v>>5) & 0x3F
+	b5 := int(v) & 0x1F
+	r = (r5 << 3) | (r5 >> 2)
+	g = (g6 << 2) | (g6 >> 4)
+	b = (b5 << 3) | (b5 >> 2)
+	return r, g, b
+}
@@ -0,0 +1,174 @@
+package pcmp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+const (
+	// DefaultBlockSizeKiB is the chunk size compressBlocks falls back to
+	// when given a non-positive block size. Writer only enters block mode
+	// with a positive size, so it always passes its own.
+	DefaultBlockSizeKiB = 64
+
+	blockIndexEntrySize = 9 // uint32 uncompressedLen + uint32 compressedLen + 1 mode byte
+)
+
+type blockIndexEntry struct {
+	uncompressedLen uint32
+	compressedLen   uint32
+	mode            byte
+}
+
+// compressBlocks splits input into blockSize chunks and, in parallel across
+// runtime.NumCPU() workers, applies mode to each chunk the same way
+// Writer.Close applies it to the whole payload in the non-block path:
+// ModeStored/ModeLZSS force that choice for every block (propagating a
+// ModeLZSS compression failure instead of silently overriding it), and
+// ModeAuto picks LZSS unless it wouldn't shrink the block. The chunks are
+// concatenated behind a small index: a block count followed by one
+// {uncompressedLen, compressedLen, mode} entry per block.
+func compressBlocks(input []byte, blockSize int, mode Mode) ([]byte, error) {
+	if blockSize <= 0 {
+		blockSize = DefaultBlockSizeKiB * 1024
+	}
+
+	var chunks [][]byte
+	for off := 0; off < len(input); off += blockSize {
+		end := off + blockSize
+		if end > len(input) {
+			end = len(input)
+		}
+		chunks = append(chunks, input[off:end])
+	}
+
+	payloads := make([][]byte, len(chunks))
+	modes := make([]byte, len(chunks))
+	errs := make([]error, len(chunks))
+	runParallel(len(chunks), func(i int) {
+		if mode == ModeStored {
+			payloads[i], modes[i] = chunks[i], flagStored
+			return
+		}
+		compressed, err := coreCompress(chunks[i])
+		switch {
+		case mode == ModeLZSS && err != nil:
+			errs[i] = err
+		case mode == ModeLZSS:
+			payloads[i], modes[i] = compressed, flagLZSS
+		case err != nil || len(compressed) >= len(chunks[i]):
+			payloads[i], modes[i] = chunks[i], flagStored
+		default:
+			payloads[i], modes[i] = compressed, flagLZSS
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index := make([]byte, 4+len(chunks)*blockIndexEntrySize)
+	binary.LittleEndian.PutUint32(index[0:4], uint32(len(chunks)))
+	off := 4
+	var body []byte
+	for i, chunk := range chunks {
+		binary.LittleEndian.PutUint32(index[off:off+4], uint32(len(chunk)))
+		binary.LittleEndian.PutUint32(index[off+4:off+8], uint32(len(payloads[i])))
+		index[off+8] = modes[i]
+		off += blockIndexEntrySize
+		body = append(body, payloads[i]...)
+	}
+	return append(index, body...), nil
+}
+
+// decompressBlocks reverses compressBlocks: it reads the block index, then
+// decodes every block concurrently straight into its known offset in the
+// pre-sized output buffer.
+func decompressBlocks(payload []byte) ([]byte, error) {
+	if len(payload) < 4 {
+		return nil, fmt.Errorf("pcmp: block payload too short for block count")
+	}
+	count := binary.LittleEndian.Uint32(payload[0:4])
+
+	entries := make([]blockIndexEntry, count)
+	off := 4
+	for i := uint32(0); i < count; i++ {
+		if off+blockIndexEntrySize > len(payload) {
+			return nil, fmt.Errorf("pcmp: truncated block index at entry %d", i)
+		}
+		entries[i] = blockIndexEntry{
+			uncompressedLen: binary.LittleEndian.Uint32(payload[off : off+4]),
+			compressedLen:   binary.LittleEndian.Uint32(payload[off+4 : off+8]),
+			mode:            payload[off+8],
+		}
+		off += blockIndexEntrySize
+	}
+
+	outOffsets := make([]int, count)
+	srcOffsets := make([]int, count)
+	outPos, srcPos := 0, off
+	for i, e := range entries {
+		outOffsets[i] = outPos
+		srcOffsets[i] = srcPos
+		outPos += int(e.uncompressedLen)
+		srcPos += int(e.compressedLen)
+		if srcOffsets[i]+int(e.compressedLen) > len(payload) {
+			return nil, fmt.Errorf("pcmp: block %d exceeds payload bounds", i)
+		}
+	}
+
+	out := make([]byte, outPos)
+	errs := make([]error, count)
+	runParallel(int(count), func(i int) {
+		e := entries[i]
+		stream := payload[srcOffsets[i] : srcOffsets[i]+int(e.compressedLen)]
+		if e.mode == flagStored {
+			copy(out[outOffsets[i]:outOffsets[i]+int(e.uncompressedLen)], stream)
+			return
+		}
+		decoded, err := lzssDecompress(stream, e.uncompressedLen)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		copy(out[outOffsets[i]:outOffsets[i]+int(e.uncompressedLen)], decoded)
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// runParallel runs fn(0), fn(1), ..., fn(n-1) across a worker pool sized to
+// runtime.NumCPU(), waiting for all of them to finish.
+func runParallel(n int, fn func(i int)) {
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,236 @@
+package pcmp
+
+// lzHash hashes the 3 bytes at inputData[pos:pos+3] into a hashChainSize
+// bucket index, LZ4-style.
+func lzHash(inputData []byte, pos int) uint32 {
+	v := uint32(inputData[pos]) | uint32(inputData[pos+1])<<8 | uint32(inputData[pos+2])<<16
+	return (v * hashMultiplier) >> (32 - hashChainBits)
+}
+
+// insertHash records pos in the hash chain for its 3-byte prefix so later
+// positions can find it as a candidate match.
+func insertHash(inputData []byte, pos int, head []int32, prev []int32) {
+	if pos+3 > len(inputData) {
+		return
+	}
+	h := lzHash(inputData, pos)
+	prev[pos&windowMask] = head[h]
+	head[h] = int32(pos)
+}
+
+// findMatch walks the hash chain for inputData[currentInputPos:]'s 3-byte
+// prefix, extending each candidate forward with a byte compare and keeping
+// the longest match found. On ties it keeps whichever candidate was found
+// first (i.e. the most recently inserted, smallest-offset one), only
+// replacing the best match when a strictly longer one turns up.
+func findMatch(inputData []byte, currentInputPos int, maxMatchLen int, head []int32, prev []int32) (offset int, length int, found bool) {
+	if currentInputPos+minMatchLength > len(inputData) {
+		return 0, 0, false
+	}
+	if currentInputPos+maxMatchLen > len(inputData) {
+		maxMatchLen = len(inputData) - currentInputPos
+	}
+	if maxMatchLen < minMatchLength {
+		return 0, 0, false
+	}
+
+	h := lzHash(inputData, currentInputPos)
+	candidate := head[h]
+	bestLength := 0
+	bestOffset := 0
+
+	for chainLen := 0; candidate >= 0 && chainLen < maxChainLength; chainLen++ {
+		c := int(candidate)
+		if currentInputPos-c > windowSize {
+			break
+		}
+
+		matchLen := 0
+		for matchLen < maxMatchLen && inputData[c+matchLen] == inputData[currentInputPos+matchLen] {
+			matchLen++
+		}
+		if matchLen > bestLength {
+			bestLength = matchLen
+			bestOffset = currentInputPos - c
+			if bestLength >= maxMatchLen {
+				break
+			}
+		}
+
+		candidate = prev[c&windowMask]
+	}
+
+	if bestLength < minMatchLength {
+		return 0, 0, false
+	}
+	return bestOffset, bestLength, true
+}
+
+// coreCompress LZSS-encodes inputData into the PCMP bitstream format: a
+// flag byte every 8 tokens (1 bit per token, MSB first) followed by either
+// a literal byte or a 2-byte offset/length pair.
+func coreCompress(inputData []byte) ([]byte, error) {
+	if len(inputData) == 0 {
+		return []byte{}, nil
+	}
+	// Worst case is an all-literal encoding: 1 flag byte per 8 literal
+	// bytes (9 output bytes per 8 input bytes), so size for that plus
+	// slack rather than guessing — an undersized buffer here used to mean
+	// coreCompress hard-failed on incompressible input instead of letting
+	// the caller fall back to stored mode.
+	estimatedOutputSize := len(inputData) + len(inputData)/8 + outputBufferSlack
+	outputBuffer := make([]byte, estimatedOutputSize)
+	inputPos, outputPos := 0, 0
+	var currentFlag byte = 0x00
+	bitCount := 0
+	flagPos := outputPos
+
+	if outputPos >= len(outputBuffer) {
+		return nil, errBufferTooSmall
+	}
+	outputBuffer[flagPos] = 0x00
+	outputPos++
+
+	head := make([]int32, hashChainSize)
+	for i := range head {
+		head[i] = -1
+	}
+	prev := make([]int32, windowSize)
+
+	for inputPos < len(inputData) {
+		if outputPos >= len(outputBuffer) {
+			return nil, errBufferTooSmall
+		}
+		currentMaxMatchLen := len(inputData) - inputPos
+		if currentMaxMatchLen > maxEncodedLength {
+			currentMaxMatchLen = maxEncodedLength
+		}
+
+		matchOffset, matchLength, matchFound := findMatch(inputData, inputPos, currentMaxMatchLen, head, prev)
+
+		if !matchFound {
+			bitCount++
+			if bitCount == 8 {
+				outputBuffer[flagPos] = currentFlag
+				currentFlag = 0x00
+				bitCount = 0
+				flagPos = outputPos
+				if outputPos >= len(outputBuffer) {
+					return nil, errBufferTooSmall
+				}
+				outputBuffer[flagPos] = 0x00
+				outputPos++
+			}
+			if outputPos >= len(outputBuffer) {
+				return nil, errBufferTooSmall
+			}
+			outputBuffer[outputPos] = inputData[inputPos]
+			insertHash(inputData, inputPos, head, prev)
+			inputPos++
+			outputPos++
+		} else {
+			currentFlag |= (1 << (7 - bitCount))
+			bitCount++
+			if bitCount == 8 {
+				outputBuffer[flagPos] = currentFlag
+				currentFlag = 0x00
+				bitCount = 0
+				flagPos = outputPos
+				if outputPos >= len(outputBuffer) {
+					return nil, errBufferTooSmall
+				}
+				outputBuffer[flagPos] = 0x00
+				outputPos++
+			}
+			if outputPos+1 >= len(outputBuffer) {
+				return nil, errBufferTooSmall
+			}
+			encodedPairVal := uint16(((matchOffset - 1) << 4) | ((matchLength - 3) & 0x0F))
+			outputBuffer[outputPos] = byte(encodedPairVal & 0xFF)
+			outputPos++
+			outputBuffer[outputPos] = byte((encodedPairVal >> 8) & 0xFF)
+			outputPos++
+			for i := 0; i < matchLength; i++ {
+				insertHash(inputData, inputPos+i, head, prev)
+			}
+			inputPos += matchLength
+		}
+	}
+	outputBuffer[flagPos] = currentFlag
+	return outputBuffer[:outputPos], nil
+}
+
+// lzssDecompress reverses coreCompress, expanding compressedStream into
+// exactly uncompressedSize bytes.
+func lzssDecompress(compressedStream []byte, uncompressedSize uint32) ([]byte, error) {
+	outBuffer := make([]byte, 0, uncompressedSize)
+	streamIdx := 0
+	streamLen := len(compressedStream)
+
+	if streamLen == 0 {
+		if uncompressedSize > 0 {
+			return nil, errStreamEmpty
+		}
+		return []byte{}, nil
+	}
+
+	controlByte := compressedStream[streamIdx]
+	streamIdx++
+	bitsLeft := 8
+
+	for uint32(len(outBuffer)) < uncompressedSize {
+		if streamIdx > streamLen {
+			return nil, errStreamPrematureEnd
+		}
+
+		isCopyOperation := (controlByte & 0x80) != 0
+		controlByte <<= 1
+		bitsLeft--
+
+		if bitsLeft == 0 {
+			if streamIdx >= streamLen {
+				if uint32(len(outBuffer)) < uncompressedSize {
+					return nil, errStreamPrematureEnd
+				}
+				break
+			}
+			controlByte = compressedStream[streamIdx]
+			streamIdx++
+			bitsLeft = 8
+		}
+
+		if isCopyOperation {
+			if streamIdx+1 >= streamLen {
+				return nil, errPrematureEndCopy
+			}
+			byte1 := compressedStream[streamIdx]
+			byte2 := compressedStream[streamIdx+1]
+			streamIdx += 2
+
+			offset := int(((uint16(byte1) >> 4) | (uint16(byte2) << 4))) + 1
+			count := int(byte1&0x0F) + 3
+
+			for i := 0; i < count; i++ {
+				if uint32(len(outBuffer)) >= uncompressedSize {
+					break
+				}
+				if offset > len(outBuffer) {
+					outBuffer = append(outBuffer, 0)
+				} else {
+					outBuffer = append(outBuffer, outBuffer[len(outBuffer)-offset])
+				}
+			}
+		} else {
+			if streamIdx >= streamLen {
+				return nil, errPrematureEndLiteral
+			}
+			if uint32(len(outBuffer)) >= uncompressedSize {
+				break
+			}
+			outBuffer = append(outBuffer, compressedStream[streamIdx])
+			streamIdx++
+		}
+	}
+
+	return outBuffer, nil
+}
@@ -0,0 +1,120 @@
+// Package pcmp implements the "PCMP" LZSS container format used by PDO
+// texture files. It exposes streaming Writer/Reader types modeled on
+// compress/gzip so callers can embed PCMP encode/decode in larger
+// pipelines instead of shelling out to the CLI tools.
+package pcmp
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	magic      = "PCMP"
+	headerSize = 0x20 // PCMP header is 32 bytes
+	dataOffset = headerSize
+
+	windowSize       = 4096
+	windowMask       = windowSize - 1
+	maxEncodedLength = 18
+	minMatchLength   = 3
+
+	hashChainBits  = 13 // head[] has 2^13 = 8192 buckets
+	hashChainSize  = 1 << hashChainBits
+	hashMultiplier = 2654435761 // LZ4-style multiplicative hash constant
+
+	// maxChainLength bounds how many candidates findMatch walks per
+	// position, trading ratio for speed (cf. deflate's "max_lazy"/
+	// zlib's "nice_match").
+	maxChainLength = 64
+
+	outputBufferSlack = 2048 // Extra space for output buffer heuristic
+
+	// DefaultAlignment is the file padding PDO expects PCMP files to be
+	// aligned to, used by NewAlignedWriter's CLI callers.
+	DefaultAlignment = 2048
+)
+
+// Mode selects the storage mode a Writer picks for its payload.
+type Mode int
+
+const (
+	// ModeAuto stores the payload as LZSS unless doing so would grow it,
+	// in which case it falls back to ModeStored.
+	ModeAuto Mode = iota
+	// ModeLZSS always emits the LZSS-compressed bitstream.
+	ModeLZSS
+	// ModeStored always emits the input verbatim.
+	ModeStored
+)
+
+// String returns the -mode flag spelling for m, for diagnostics like
+// reporting which mode Writer.Close actually picked.
+func (m Mode) String() string {
+	switch m {
+	case ModeLZSS:
+		return "lzss"
+	case ModeStored:
+		return "stored"
+	default:
+		return "auto"
+	}
+}
+
+// Storage modes as recorded in header[4].
+const (
+	flagLZSS   byte = 0x00
+	flagStored byte = 0x01
+)
+
+// Block mode, as recorded in header[5] (previously always zeroed). When
+// set, the payload is a block index followed by independently compressed
+// blocks instead of a single LZSS/stored stream; see block.go.
+const (
+	flagBlockModeOff byte = 0x00
+	flagBlockModeOn  byte = 0x01
+)
+
+var (
+	errBufferTooSmall      = errors.New("pcmp: compressed size exceeds allocated buffer heuristic")
+	errFileTooShort        = errors.New("pcmp: file too short for PCMP header")
+	errInvalidSignature    = errors.New("pcmp: 'PCMP' signature not found")
+	errStreamEmpty         = errors.New("pcmp: compressed stream is empty")
+	errStreamPrematureEnd  = errors.New("pcmp: compressed stream ended prematurely")
+	errPrematureEndCopy    = errors.New("pcmp: premature end while reading copy block")
+	errPrematureEndLiteral = errors.New("pcmp: premature end while reading literal")
+)
+
+// buildHeader assembles a 32-byte PCMP header for the given sizes, storage
+// mode and block-mode flag.
+func buildHeader(totalUncompressedSize, totalCompressedStreamSize uint32, mode, blockFlag byte) []byte {
+	header := make([]byte, headerSize)
+	copy(header[0:4], magic)
+	header[4] = mode
+	header[5] = blockFlag
+	binary.LittleEndian.PutUint32(header[0x14:0x18], totalUncompressedSize)
+	binary.LittleEndian.PutUint32(header[0x18:0x1C], totalCompressedStreamSize)
+	return header
+}
+
+// readHeader parses a PCMP header, returning the uncompressed size,
+// compressed stream size, storage mode and block-mode flag it declares.
+func readHeader(buf []byte) (outSize, compSize uint32, mode, blockFlag byte, err error) {
+	if len(buf) < headerSize {
+		return 0, 0, 0, 0, errFileTooShort
+	}
+	if string(buf[:4]) != magic {
+		return 0, 0, 0, 0, errInvalidSignature
+	}
+
+	mode = buf[4]
+	blockFlag = buf[5]
+	outSize = binary.LittleEndian.Uint32(buf[0x14:0x18])
+	compSize = binary.LittleEndian.Uint32(buf[0x18:0x1C])
+
+	remainingDataInFile := uint32(len(buf) - headerSize)
+	if compSize == 0 || compSize > remainingDataInFile {
+		compSize = remainingDataInFile
+	}
+	return outSize, compSize, mode, blockFlag, nil
+}
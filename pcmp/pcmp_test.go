@@ -0,0 +1,124 @@
+package pcmp
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// roundTrip encodes input through a Writer configured by configure, then
+// decodes it back through a Reader and returns the result.
+func roundTrip(t *testing.T, input []byte, configure func(*Writer)) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if configure != nil {
+		configure(w)
+	}
+	if _, err := w.Write(input); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	return out
+}
+
+func TestRoundTripLZSS(t *testing.T) {
+	// Highly repetitive input compresses well, so forcing ModeLZSS runs
+	// the encoder and decoder over many real back-references.
+	input := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog "), 200)
+
+	out := roundTrip(t, input, func(w *Writer) { w.SetMode(ModeLZSS) })
+	if !bytes.Equal(out, input) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d", len(out), len(input))
+	}
+}
+
+func TestRoundTripStoredIncompressible(t *testing.T) {
+	// Random data is incompressible: ModeAuto must fall back to stored
+	// mode (and the undersized-output-buffer bug that used to hard-fail
+	// on this exact case must stay fixed).
+	rng := rand.New(rand.NewSource(1))
+	input := make([]byte, 200000)
+	rng.Read(input)
+
+	if _, err := coreCompress(input); err != nil {
+		t.Fatalf("coreCompress: %v", err)
+	}
+
+	var w *Writer
+	out := roundTrip(t, input, func(cw *Writer) { w = cw })
+	if !bytes.Equal(out, input) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d", len(out), len(input))
+	}
+	if w.Mode() != ModeStored {
+		t.Fatalf("Mode() = %v, want ModeStored", w.Mode())
+	}
+}
+
+func TestRoundTripBlocked(t *testing.T) {
+	// Mix a compressible block with an incompressible one so each block
+	// must independently pick its own auto mode.
+	rng := rand.New(rand.NewSource(2))
+	compressible := bytes.Repeat([]byte("abcdefgh"), 4096)
+	random := make([]byte, 32*1024)
+	rng.Read(random)
+	input := append(append([]byte{}, compressible...), random...)
+
+	out := roundTrip(t, input, func(w *Writer) { w.SetBlockSize(16) })
+	if !bytes.Equal(out, input) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d", len(out), len(input))
+	}
+}
+
+func TestBlockModeHonorsForcedStoredMode(t *testing.T) {
+	// Repetitive input that compressBlocks would otherwise pick LZSS for;
+	// ModeStored must still force every block to be stored verbatim.
+	input := bytes.Repeat([]byte("abcdefgh"), 10000)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.SetMode(ModeStored)
+	w.SetBlockSize(8)
+	if _, err := w.Write(input); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if w.Mode() != ModeStored {
+		t.Fatalf("Mode() = %v, want ModeStored", w.Mode())
+	}
+	// A forced-stored encode can't shrink the payload below its input
+	// size plus the block index overhead; a smaller stream here would
+	// mean some block silently picked LZSS instead.
+	numBlocks := (len(input) + 8*1024 - 1) / (8 * 1024)
+	minStoredSize := headerSize + 4 + numBlocks*blockIndexEntrySize + len(input)
+	if buf.Len() < minStoredSize {
+		t.Fatalf("stream is %d bytes, shorter than forced-stored floor of %d; a block must have been LZSS-compressed", buf.Len(), minStoredSize)
+	}
+
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(out, input) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d", len(out), len(input))
+	}
+}
@@ -0,0 +1,65 @@
+package pcmp
+
+import (
+	"fmt"
+	"io"
+)
+
+// Reader decodes a PCMP stream. Since the format's header (and so the
+// declared uncompressed size) sits at the front of the file rather than
+// trailing it, NewReader reads and decodes the whole stream up front; Read
+// then just serves out of the decoded buffer.
+type Reader struct {
+	data []byte
+	pos  int
+}
+
+// NewReader validates the "PCMP" magic and decodes the stream read from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("pcmp: reading stream: %w", err)
+	}
+
+	outSize, compSize, mode, blockFlag, err := readHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+	if dataOffset+int(compSize) > len(raw) {
+		return nil, fmt.Errorf("pcmp: compressed stream size (%d) + offset (%d) exceeds input length (%d)", compSize, dataOffset, len(raw))
+	}
+
+	stream := raw[dataOffset : dataOffset+int(compSize)]
+
+	var decoded []byte
+	switch {
+	case blockFlag == flagBlockModeOn:
+		decoded, err = decompressBlocks(stream)
+		if err != nil {
+			return nil, err
+		}
+	case mode == flagStored:
+		decoded = append([]byte(nil), stream...)
+	default:
+		decoded, err = lzssDecompress(stream, outSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if uint32(len(decoded)) > outSize {
+		decoded = decoded[:outSize]
+	}
+
+	return &Reader{data: decoded}, nil
+}
+
+// Read implements io.Reader, returning io.EOF once the decoded payload's
+// declared uncompressed size has been reached.
+func (cr *Reader) Read(p []byte) (int, error) {
+	if cr.pos >= len(cr.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, cr.data[cr.pos:])
+	cr.pos += n
+	return n, nil
+}
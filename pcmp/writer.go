@@ -0,0 +1,162 @@
+package pcmp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Writer buffers written data and LZSS-encodes it into a PCMP stream on
+// Close, once the total size is known and the header can be written. This
+// mirrors gzip.Writer's Write/Close contract, but PCMP's header is written
+// up front rather than trailing, so it can only be emitted once the whole
+// payload has been buffered.
+type Writer struct {
+	w           io.Writer
+	buf         bytes.Buffer
+	mode        Mode
+	blockSizeKB int
+	closed      bool
+	chosenMode  Mode
+}
+
+// NewWriter returns a Writer that encodes everything written to it as a
+// PCMP stream to w once Close is called.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// SetMode overrides the storage mode Close picks; the default is ModeAuto.
+func (cw *Writer) SetMode(m Mode) {
+	cw.mode = m
+}
+
+// SetBlockSize enables block mode, splitting the payload into
+// kibibyte-sized chunks compressed in parallel (see block.go). A
+// non-positive size disables block mode, which is the default.
+func (cw *Writer) SetBlockSize(kib int) {
+	cw.blockSizeKB = kib
+}
+
+// Mode reports the storage mode Close actually used, once Close has
+// returned successfully — in particular, whether a ModeAuto encode fell
+// back to ModeStored. In block mode, where each block picks independently
+// (see block.go), it reports ModeAuto rather than any single block's
+// choice unless the caller forced ModeLZSS or ModeStored for every block.
+func (cw *Writer) Mode() Mode {
+	return cw.chosenMode
+}
+
+func (cw *Writer) Write(p []byte) (int, error) {
+	if cw.closed {
+		return 0, errors.New("pcmp: write to closed Writer")
+	}
+	return cw.buf.Write(p)
+}
+
+// Close LZSS-compresses the buffered input, picks a storage mode, and
+// writes the PCMP header and payload to the underlying writer.
+func (cw *Writer) Close() error {
+	if cw.closed {
+		return nil
+	}
+	cw.closed = true
+
+	input := cw.buf.Bytes()
+
+	if cw.blockSizeKB > 0 && len(input) > 0 {
+		payload, err := compressBlocks(input, cw.blockSizeKB*1024, cw.mode)
+		if err != nil {
+			return err
+		}
+		cw.chosenMode = cw.mode
+		header := buildHeader(uint32(len(input)), uint32(len(payload)), flagLZSS, flagBlockModeOn)
+		if _, err := cw.w.Write(header); err != nil {
+			return err
+		}
+		_, err = cw.w.Write(payload)
+		return err
+	}
+
+	compressed, compressErr := coreCompress(input)
+
+	flag, payload := flagLZSS, compressed
+	switch {
+	case cw.mode == ModeLZSS && compressErr != nil:
+		return compressErr
+	case cw.mode == ModeStored:
+		flag, payload = flagStored, input
+	case cw.mode == ModeLZSS:
+		flag, payload = flagLZSS, compressed
+	case compressErr != nil || len(compressed) >= len(input):
+		// coreCompress failing (e.g. a buffer heuristic miss) is itself a
+		// sign LZSS isn't shrinking this input, so fall back to stored
+		// mode exactly as we would if it merely came back longer.
+		flag, payload = flagStored, input
+	}
+
+	cw.chosenMode = ModeLZSS
+	if flag == flagStored {
+		cw.chosenMode = ModeStored
+	}
+
+	header := buildHeader(uint32(len(input)), uint32(len(payload)), flag, flagBlockModeOff)
+	if _, err := cw.w.Write(header); err != nil {
+		return err
+	}
+	_, err := cw.w.Write(payload)
+	return err
+}
+
+// AlignedWriter wraps a Writer to pad the finished PCMP stream up to a
+// fixed alignment before writing it out, matching the on-disk layout PDO
+// texture files expect.
+type AlignedWriter struct {
+	inner     *Writer
+	buf       *bytes.Buffer
+	w         io.Writer
+	alignment int
+}
+
+// NewAlignedWriter returns an AlignedWriter that pads the PCMP stream to a
+// multiple of alignment bytes with zeroes before writing it to w.
+func NewAlignedWriter(w io.Writer, alignment int) *AlignedWriter {
+	buf := &bytes.Buffer{}
+	return &AlignedWriter{inner: NewWriter(buf), buf: buf, w: w, alignment: alignment}
+}
+
+// SetMode overrides the storage mode the wrapped Writer picks.
+func (aw *AlignedWriter) SetMode(m Mode) {
+	aw.inner.SetMode(m)
+}
+
+// SetBlockSize enables block mode on the wrapped Writer.
+func (aw *AlignedWriter) SetBlockSize(kib int) {
+	aw.inner.SetBlockSize(kib)
+}
+
+// Mode reports the storage mode the wrapped Writer actually used; see
+// Writer.Mode.
+func (aw *AlignedWriter) Mode() Mode {
+	return aw.inner.Mode()
+}
+
+func (aw *AlignedWriter) Write(p []byte) (int, error) {
+	return aw.inner.Write(p)
+}
+
+// Close finishes the wrapped Writer, pads its output to the configured
+// alignment, and writes the result to the underlying writer.
+func (aw *AlignedWriter) Close() error {
+	if err := aw.inner.Close(); err != nil {
+		return err
+	}
+	data := aw.buf.Bytes()
+	if aw.alignment > 0 {
+		if remainder := len(data) % aw.alignment; remainder != 0 {
+			data = append(data, make([]byte, aw.alignment-remainder)...)
+		}
+	}
+	_, err := aw.w.Write(data)
+	return err
+}